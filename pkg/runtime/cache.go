@@ -0,0 +1,88 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Cache is a pluggable content-addressable store for build artifacts, keyed
+// by an opaque hash computed by the caller. Runtimes use it to skip
+// re-invoking their underlying toolchain when nothing the key depends on
+// has changed.
+type Cache interface {
+	// Get copies the cached artifact for key to dst. The second return
+	// value is false if there is no entry for key.
+	Get(ctx context.Context, key string, dst string) (bool, error)
+	// Put stores src under key for future Get calls.
+	Put(ctx context.Context, key string, src string) error
+}
+
+// FsCache is a Cache backed by a directory on the local filesystem, with one
+// subdirectory per key. It is the default cache used when a runtime isn't
+// configured with a remote backend.
+type FsCache struct {
+	dir string
+}
+
+// NewFsCache returns a Cache rooted at dir, creating it if necessary.
+func NewFsCache(dir string) (*FsCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FsCache{dir: dir}, nil
+}
+
+func (c *FsCache) path(key, name string) string {
+	return filepath.Join(c.dir, key, name)
+}
+
+func (c *FsCache) Get(ctx context.Context, key string, dst string) (bool, error) {
+	src := c.path(key, filepath.Base(dst))
+	in, err := os.Open(src)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return false, err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return false, err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return false, err
+	}
+	return true, out.Sync()
+}
+
+func (c *FsCache) Put(ctx context.Context, key string, src string) error {
+	dst := c.path(key, filepath.Base(src))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
@@ -0,0 +1,108 @@
+// Package builder runs `cargo lambda build` inside a pinned container image
+// instead of on the host toolchain, so the output doesn't depend on whatever
+// rustc/zig/cargo-lambda versions happen to be installed locally.
+package builder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Binary locates a container runtime on $PATH, preferring docker, then
+// podman, then finch.
+func Binary() (string, error) {
+	for _, bin := range []string{"docker", "podman", "finch"} {
+		if path, err := exec.LookPath(bin); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("builder: no container runtime found on PATH (tried docker, podman, finch)")
+}
+
+// Request describes one containerized build.
+type Request struct {
+	Image        string
+	Root         string
+	Architecture string
+	Args         []string
+	HandlerName  string
+	// TargetDir is the resolved cargo target-dir on the host, honoring a
+	// custom `.cargo/config.toml` build.target-dir rather than assuming
+	// Root/target. It must be inside Root, since Root is what's bind-mounted
+	// into the container.
+	TargetDir string
+	// Dst is the host path the resulting `bootstrap` binary is written to.
+	Dst string
+}
+
+// Build runs `cargo <Args...>` for Root inside Image, bind-mounting Root
+// and a persistent cargo registry/target cache volume keyed by
+// architecture, then copies the resulting bootstrap binary out to Dst.
+// combinedOutput carries the build step's stdout+stderr regardless of exit
+// status, for surfacing failures the way the local/remote executors do.
+func Build(ctx context.Context, req Request) (combinedOutput []byte, err error) {
+	bin, err := Binary()
+	if err != nil {
+		return nil, err
+	}
+
+	containerTargetDir, err := containerTargetDir(req.Root, req.TargetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	registryVolume := "sst-rust-cargo-registry"
+	targetVolume := "sst-rust-target-" + req.Architecture
+
+	runArgs := []string{
+		"run", "--rm",
+		"-v", req.Root + ":/workspace",
+		"-v", registryVolume + ":/usr/local/cargo/registry",
+		"-v", targetVolume + ":" + containerTargetDir,
+		"-w", "/workspace",
+	}
+	for _, e := range os.Environ() {
+		if strings.HasPrefix(e, "AWS_") || strings.HasPrefix(e, "CARGO_") {
+			runArgs = append(runArgs, "-e", e)
+		}
+	}
+	runArgs = append(runArgs, req.Image, "cargo")
+	runArgs = append(runArgs, req.Args...)
+
+	cmd := exec.CommandContext(ctx, bin, runArgs...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return output, err
+	}
+
+	extractArgs := []string{
+		"run", "--rm",
+		"-v", targetVolume + ":" + containerTargetDir,
+		req.Image, "cat",
+		path.Join(containerTargetDir, "lambda", req.HandlerName, "bootstrap"),
+	}
+	data, err := exec.CommandContext(ctx, bin, extractArgs...).Output()
+	if err != nil {
+		return output, fmt.Errorf("builder: extracting bootstrap: %w", err)
+	}
+	if err := os.WriteFile(req.Dst, data, 0755); err != nil {
+		return output, err
+	}
+	return output, nil
+}
+
+// containerTargetDir maps a host target-dir under root to its path inside
+// the container, where root is bind-mounted at /workspace. Returns an error
+// if targetDir isn't inside root, since there's nothing to mount it from.
+func containerTargetDir(root, targetDir string) (string, error) {
+	rel, err := filepath.Rel(root, targetDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("builder: target-dir %q is not inside root %q", targetDir, root)
+	}
+	return path.Join("/workspace", filepath.ToSlash(rel)), nil
+}
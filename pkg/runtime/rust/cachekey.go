@@ -0,0 +1,165 @@
+package rust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// cacheKeyInput is the set of values that determine whether a previous
+// build's `bootstrap` binary can be reused instead of invoking
+// `cargo lambda build` again. Any change to a field here must invalidate
+// the cache.
+type cacheKeyInput struct {
+	CargoToml        string   `json:"cargoToml"`
+	CargoLock        string   `json:"cargoLock"`
+	CargoConfig      string   `json:"cargoConfig"`
+	RequiredFeatures []string `json:"requiredFeatures"`
+	Architecture     string   `json:"architecture"`
+	Dev              bool     `json:"dev"`
+	Executor         string   `json:"executor"`
+	Container        bool     `json:"container"`
+	BuilderImage     string   `json:"builderImage"`
+	Sources          []string `json:"sources"`
+}
+
+// buildCacheKey hashes everything that affects the output of a Rust lambda
+// build: the Cargo manifests, the resolved .cargo/config.toml, the feature
+// set, the target architecture/mode, where the build actually runs, and
+// every .rs file reachable under sourceRoots that isn't excluded by
+// .gitignore. sourceRoots is the resolved crate's own directory plus every
+// local path dependency it transitively pulls in (see
+// transitiveLocalDeps), not the whole workspace, so editing an unrelated
+// workspace member doesn't invalidate this crate's cache entry while
+// editing a shared path-dependency crate still does.
+func buildCacheKey(lockRoot string, sourceRoots []string, cargoTomlFile string, cargoConfigFile *string, requiredFeatures []string, properties Properties, dev bool) (string, error) {
+	in := cacheKeyInput{
+		RequiredFeatures: requiredFeatures,
+		Architecture:     properties.Architecture,
+		Dev:              dev,
+		Executor:         properties.Executor,
+		Container:        properties.Container,
+		BuilderImage:     properties.BuilderImage,
+	}
+
+	if b, err := os.ReadFile(cargoTomlFile); err == nil {
+		in.CargoToml = hashBytes(b)
+	}
+	if b, err := os.ReadFile(filepath.Join(lockRoot, "Cargo.lock")); err == nil {
+		in.CargoLock = hashBytes(b)
+	}
+	if cargoConfigFile != nil {
+		if b, err := os.ReadFile(*cargoConfigFile); err == nil {
+			in.CargoConfig = hashBytes(b)
+		}
+	}
+
+	var sources []string
+	for _, root := range sourceRoots {
+		entries, err := hashSources(root)
+		if err != nil {
+			return "", err
+		}
+		for _, e := range entries {
+			sources = append(sources, root+":"+e)
+		}
+	}
+	in.Sources = sources
+	sort.Strings(in.Sources)
+
+	encoded, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	return hashBytes(encoded), nil
+}
+
+// listTrackedFiles walks root and returns the root-relative path of every
+// file keep accepts, skipping anything matched by a .gitignore found at
+// root as well as the target/ and .git/ directories.
+func listTrackedFiles(root string, keep func(rel string, d fs.DirEntry) bool) ([]string, error) {
+	matcher, _ := ignore.CompileIgnoreFile(filepath.Join(root, ".gitignore"))
+
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return relErr
+		}
+		if rel == "." {
+			return nil
+		}
+		if matcher != nil && matcher.MatchesPath(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == "target" || d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !keep(rel, d) {
+			return nil
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// listSourceFiles walks root and returns the root-relative path of every
+// .rs file, skipping anything matched by a .gitignore found at root as well
+// as the target/ and .git/ directories.
+func listSourceFiles(root string) ([]string, error) {
+	return listTrackedFiles(root, func(rel string, d fs.DirEntry) bool {
+		return filepath.Ext(rel) == ".rs"
+	})
+}
+
+// listManifestFiles walks root and returns the root-relative path of every
+// Cargo.toml, so a workspace build can upload each member's manifest
+// alongside its sources -- cargo can't resolve a --package build against a
+// tree that's missing them.
+func listManifestFiles(root string) ([]string, error) {
+	return listTrackedFiles(root, func(rel string, d fs.DirEntry) bool {
+		return d.Name() == "Cargo.toml"
+	})
+}
+
+// hashSources returns "<relpath>:<sha256>" entries for every .rs file
+// reachable under root, suitable for folding into a cache key.
+func hashSources(root string) ([]string, error) {
+	paths, err := listSourceFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]string, 0, len(paths))
+	for _, rel := range paths {
+		b, err := os.ReadFile(filepath.Join(root, rel))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, rel+":"+hashBytes(b))
+	}
+	return entries, nil
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,130 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func TestBuildCacheKeyIgnoresSourcesOutsideSourceRoot(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "Cargo.lock"), "lock-v1")
+	writeFile(t, filepath.Join(ws, "a", "Cargo.toml"), "[package]\nname = \"a\"")
+	writeFile(t, filepath.Join(ws, "a", "src", "main.rs"), "fn main() {}")
+	writeFile(t, filepath.Join(ws, "b", "Cargo.toml"), "[package]\nname = \"b\"")
+	writeFile(t, filepath.Join(ws, "b", "src", "main.rs"), "fn main() {}")
+
+	props := Properties{Architecture: "x86_64"}
+	aDir := filepath.Join(ws, "a")
+	before, err := buildCacheKey(ws, []string{aDir}, filepath.Join(aDir, "Cargo.toml"), nil, nil, props, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+
+	// Editing a sibling workspace member's source must not change a's key.
+	writeFile(t, filepath.Join(ws, "b", "src", "main.rs"), "fn main() { println!(\"changed\"); }")
+	after, err := buildCacheKey(ws, []string{aDir}, filepath.Join(aDir, "Cargo.toml"), nil, nil, props, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+	if before != after {
+		t.Fatalf("expected cache key for crate a to be unaffected by a change in crate b")
+	}
+
+	// Editing a's own source must change its key.
+	writeFile(t, filepath.Join(aDir, "src", "main.rs"), "fn main() { println!(\"changed\"); }")
+	changed, err := buildCacheKey(ws, []string{aDir}, filepath.Join(aDir, "Cargo.toml"), nil, nil, props, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+	if changed == before {
+		t.Fatalf("expected cache key for crate a to change after editing its own source")
+	}
+}
+
+func TestBuildCacheKeyDistinguishesExecutorConfig(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "Cargo.toml"), "[package]\nname = \"a\"")
+	writeFile(t, filepath.Join(ws, "src", "main.rs"), "fn main() {}")
+
+	host, err := buildCacheKey(ws, []string{ws}, filepath.Join(ws, "Cargo.toml"), nil, nil, Properties{Architecture: "x86_64"}, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+	container, err := buildCacheKey(ws, []string{ws}, filepath.Join(ws, "Cargo.toml"), nil, nil, Properties{Architecture: "x86_64", Container: true}, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+	if host == container {
+		t.Fatalf("expected toggling Container to change the cache key")
+	}
+}
+
+func TestBuildCacheKeyChangesWhenLocalPathDependencyEdited(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "Cargo.lock"), "lock-v1")
+	writeFile(t, filepath.Join(ws, "common", "Cargo.toml"), "[package]\nname = \"common\"")
+	writeFile(t, filepath.Join(ws, "common", "src", "lib.rs"), "pub fn hello() {}")
+	writeFile(t, filepath.Join(ws, "app", "Cargo.toml"), `
+[package]
+name = "app"
+
+[dependencies]
+common = { path = "../common" }
+`)
+	writeFile(t, filepath.Join(ws, "app", "src", "main.rs"), "fn main() {}")
+
+	appDir := filepath.Join(ws, "app")
+	props := Properties{Architecture: "x86_64"}
+	roots := transitiveLocalDeps(appDir)
+
+	before, err := buildCacheKey(ws, roots, filepath.Join(appDir, "Cargo.toml"), nil, nil, props, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+
+	// Editing the path dependency's source, not app's own, must still bust
+	// the cache: app's built bytes depend on common's source too.
+	writeFile(t, filepath.Join(ws, "common", "src", "lib.rs"), "pub fn hello() { println!(\"changed\"); }")
+	after, err := buildCacheKey(ws, roots, filepath.Join(appDir, "Cargo.toml"), nil, nil, props, false)
+	if err != nil {
+		t.Fatalf("buildCacheKey: %v", err)
+	}
+	if before == after {
+		t.Fatalf("expected cache key to change after editing a local path dependency")
+	}
+}
+
+func TestTransitiveLocalDepsFollowsPathDependencies(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "common", "Cargo.toml"), "[package]\nname = \"common\"")
+	writeFile(t, filepath.Join(ws, "app", "Cargo.toml"), `
+[package]
+name = "app"
+
+[dependencies]
+common = { path = "../common" }
+serde = "1"
+`)
+
+	dirs := transitiveLocalDeps(filepath.Join(ws, "app"))
+	if len(dirs) != 2 {
+		t.Fatalf("expected app + common, got %v", dirs)
+	}
+	want := map[string]bool{filepath.Join(ws, "app"): true, filepath.Join(ws, "common"): true}
+	for _, d := range dirs {
+		if !want[d] {
+			t.Fatalf("unexpected dir %q in %v", d, dirs)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Package daemon keeps a long-running `cargo watch` process alive per
+// function in dev mode, instead of re-invoking `cargo lambda build` from
+// scratch on every file save.
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sst/sst/v3/pkg/process"
+)
+
+// DefaultDebounce is how long JobQueue waits after the last trigger for a
+// function before running its job, coalescing bursts of file events (e.g.
+// an editor writing a file in several chunks) into one run.
+const DefaultDebounce = 250 * time.Millisecond
+
+// JobQueue coalesces triggers for a function within a debounce window and
+// ensures only one job runs per function at a time: a trigger that arrives
+// while a job is already running is recorded and re-fires the job once it
+// finishes, rather than running concurrently.
+type JobQueue struct {
+	mu       sync.Mutex
+	debounce time.Duration
+	job      func(ctx context.Context, functionID string)
+	timers   map[string]*time.Timer
+	running  map[string]bool
+	pending  map[string]bool
+	wg       sync.WaitGroup
+	closed   bool
+}
+
+// NewJobQueue creates a JobQueue that runs job for a functionID once
+// debounce has elapsed since its last Trigger call. A debounce <= 0 uses
+// DefaultDebounce.
+func NewJobQueue(debounce time.Duration, job func(ctx context.Context, functionID string)) *JobQueue {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	return &JobQueue{
+		debounce: debounce,
+		job:      job,
+		timers:   map[string]*time.Timer{},
+		running:  map[string]bool{},
+		pending:  map[string]bool{},
+	}
+}
+
+// Trigger schedules job to run for functionID after the debounce window,
+// resetting the window if it's already pending.
+func (q *JobQueue) Trigger(ctx context.Context, functionID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	if t, ok := q.timers[functionID]; ok {
+		t.Stop()
+	}
+	q.timers[functionID] = time.AfterFunc(q.debounce, func() {
+		q.fire(ctx, functionID)
+	})
+}
+
+func (q *JobQueue) fire(ctx context.Context, functionID string) {
+	q.mu.Lock()
+	delete(q.timers, functionID)
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	if q.running[functionID] {
+		q.pending[functionID] = true
+		q.mu.Unlock()
+		return
+	}
+	q.running[functionID] = true
+	q.wg.Add(1)
+	q.mu.Unlock()
+
+	go q.run(ctx, functionID)
+}
+
+func (q *JobQueue) run(ctx context.Context, functionID string) {
+	defer q.wg.Done()
+	q.job(ctx, functionID)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.running, functionID)
+	if q.pending[functionID] {
+		delete(q.pending, functionID)
+		q.running[functionID] = true
+		q.wg.Add(1)
+		go q.run(ctx, functionID)
+	}
+}
+
+// Shutdown stops accepting new triggers, runs any job still waiting out its
+// debounce window right away (with ctx), and waits for every job, including
+// those, to finish or ctx to be cancelled.
+func (q *JobQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	for id, t := range q.timers {
+		t.Stop()
+		delete(q.timers, id)
+		if q.running[id] {
+			q.pending[id] = true
+			continue
+		}
+		q.running[id] = true
+		q.wg.Add(1)
+		go q.run(ctx, id)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WatchEvent reports one build `cargo watch` ran: its exit status and the
+// output produced since the previous event. It's parsed from cargo watch's
+// own "[Finished running. Exit status: N]" marker, so callers only react to
+// an actual completed build instead of guessing from a timer.
+type WatchEvent struct {
+	ExitCode int
+	Output   string
+}
+
+// finishedRe matches the line cargo watch prints after each run, e.g.
+// "[Finished running. Exit status: 0]".
+var finishedRe = regexp.MustCompile(`^\[Finished running\. Exit status: (\d+)\]$`)
+
+// Watcher wraps a long-lived child process (typically `cargo watch`) whose
+// output is scanned for its completion marker and reported through
+// WatchEvent.
+type Watcher struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+}
+
+// StartWatcher launches `cargo <args...>` in dir with env and leaves it
+// running; the caller is responsible for calling Stop when it's no longer
+// needed. onEvent, if non-nil, is called once per build cargo watch runs.
+func StartWatcher(dir string, args []string, env []string, onEvent func(WatchEvent)) (*Watcher, error) {
+	cmd := process.Command("cargo", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	w := &Watcher{cmd: cmd, stdout: stdout, stderr: stderr}
+	if onEvent != nil {
+		go w.scanEvents(onEvent)
+	}
+	return w, nil
+}
+
+// scanEvents is the sole reader of the watcher's combined output: it splits
+// on cargo watch's completion marker and hands each build's output to
+// onEvent as it finishes.
+func (w *Watcher) scanEvents(onEvent func(WatchEvent)) {
+	reader, writer := io.Pipe()
+	go func() {
+		defer writer.Close()
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(writer, w.stdout)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(writer, w.stderr)
+		}()
+		wg.Wait()
+	}()
+
+	var buf strings.Builder
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := finishedRe.FindStringSubmatch(line); m != nil {
+			exitCode, _ := strconv.Atoi(m[1])
+			onEvent(WatchEvent{ExitCode: exitCode, Output: buf.String()})
+			buf.Reset()
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+}
+
+// Stop kills the watcher's child process.
+func (w *Watcher) Stop() {
+	process.Kill(w.cmd.Process)
+}
@@ -0,0 +1,104 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobQueueDebouncesBurstsIntoOneRun(t *testing.T) {
+	var runs int32
+	q := NewJobQueue(20*time.Millisecond, func(ctx context.Context, functionID string) {
+		atomic.AddInt32(&runs, 1)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		q.Trigger(ctx, "fn-a")
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := q.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Fatalf("expected exactly 1 run for a debounced burst, got %d", got)
+	}
+}
+
+func TestJobQueueKeepsFunctionsIndependent(t *testing.T) {
+	seen := map[string]int{}
+	var mu sync.Mutex
+	q := NewJobQueue(10*time.Millisecond, func(ctx context.Context, functionID string) {
+		mu.Lock()
+		seen[functionID]++
+		mu.Unlock()
+	})
+
+	ctx := context.Background()
+	q.Trigger(ctx, "fn-a")
+	q.Trigger(ctx, "fn-b")
+
+	if err := q.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if seen["fn-a"] != 1 || seen["fn-b"] != 1 {
+		t.Fatalf("expected one run each for fn-a and fn-b, got %v", seen)
+	}
+}
+
+func TestJobQueueRerunsAfterTriggerDuringInFlightJob(t *testing.T) {
+	var runs int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	q := NewJobQueue(1*time.Millisecond, func(ctx context.Context, functionID string) {
+		n := atomic.AddInt32(&runs, 1)
+		if n == 1 {
+			close(started)
+			<-release
+		}
+	})
+
+	ctx := context.Background()
+	q.Trigger(ctx, "fn-a")
+	<-started
+
+	// Arrives while the first run is still in flight; should cause a second
+	// run once it completes, rather than being dropped or run concurrently.
+	q.Trigger(ctx, "fn-a")
+	close(release)
+
+	if err := q.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&runs); got != 2 {
+		t.Fatalf("expected 2 runs (in-flight + re-fire), got %d", got)
+	}
+}
+
+func TestJobQueueShutdownRejectsNewTriggers(t *testing.T) {
+	var runs int32
+	q := NewJobQueue(1*time.Millisecond, func(ctx context.Context, functionID string) {
+		atomic.AddInt32(&runs, 1)
+	})
+
+	ctx := context.Background()
+	if err := q.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	q.Trigger(ctx, "fn-a")
+	time.Sleep(10 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&runs); got != 0 {
+		t.Fatalf("expected no runs after Shutdown, got %d", got)
+	}
+}
@@ -0,0 +1,183 @@
+package rust
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/sst/sst/v3/pkg/process"
+	"github.com/sst/sst/v3/pkg/runtime/rust/builder"
+	"github.com/sst/sst/v3/pkg/runtime/rust/remote"
+)
+
+// buildRequest is everything an Executor needs to produce a `bootstrap`
+// binary for a single handler.
+type buildRequest struct {
+	Root         string
+	HandlerName  string
+	Args         []string
+	Architecture string
+	TargetDir    string
+}
+
+// Executor runs `cargo lambda build` for a resolved handler, returning the
+// path to the `bootstrap` binary it produced, or the build output when the
+// build itself fails.
+type Executor interface {
+	Build(ctx context.Context, req buildRequest) (string, []string, error)
+}
+
+// LocalExecutor runs cargo on the host toolchain. This is the long-standing
+// default behavior.
+type LocalExecutor struct{}
+
+func (LocalExecutor) Build(ctx context.Context, req buildRequest) (string, []string, error) {
+	cmd := process.Command("cargo", req.Args...)
+	cmd.Dir = req.Root
+	cmd.Env = os.Environ()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", []string{string(output)}, nil
+	}
+	src := filepath.Join(req.TargetDir, "lambda", req.HandlerName, "bootstrap")
+	return src, nil, nil
+}
+
+// RemoteExecutor farms the build out to a remote execution server, falling
+// back to a local build if the transport itself fails (as opposed to the
+// build failing, which is reported back like any other build error).
+type RemoteExecutor struct {
+	Endpoint string
+}
+
+func (e RemoteExecutor) Build(ctx context.Context, req buildRequest) (string, []string, error) {
+	src, errs, err := e.buildRemote(ctx, req)
+	if err != nil {
+		// Transport-level failure: fall back to local rather than fail the
+		// whole deploy over a flaky build server.
+		slog.Warn("remote build failed, falling back to local", "endpoint", e.Endpoint, "handler", req.HandlerName, "err", err)
+		return LocalExecutor{}.Build(ctx, req)
+	}
+	return src, errs, nil
+}
+
+func (e RemoteExecutor) buildRemote(ctx context.Context, req buildRequest) (string, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, remote.DefaultTimeout)
+	defer cancel()
+
+	client, err := remote.Dial(ctx, e.Endpoint)
+	if err != nil {
+		return "", nil, err
+	}
+	defer client.Close()
+
+	files, err := collectSources(req.Root)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rootDigest, blobs := remote.BuildTree(files)
+	if err := client.Upload(ctx, blobs); err != nil {
+		return "", nil, err
+	}
+
+	result, err := client.Execute(ctx, remote.Action{
+		RootDigest:   rootDigest,
+		Args:         req.Args,
+		Dir:          ".",
+		Architecture: req.Architecture,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if result.ExitCode != 0 {
+		return "", []string{result.CombinedOutput}, nil
+	}
+
+	data, err := client.Download(ctx, result.OutputDigest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dst := filepath.Join(req.Root, "target", "lambda", req.HandlerName, "bootstrap")
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", nil, err
+	}
+	if err := os.WriteFile(dst, data, 0755); err != nil {
+		return "", nil, err
+	}
+	return dst, nil, nil
+}
+
+// ContainerExecutor runs cargo lambda build inside a pinned builder image so
+// the output doesn't depend on the host's installed rustc/zig/cargo-lambda
+// versions.
+type ContainerExecutor struct {
+	Image string
+}
+
+func (e ContainerExecutor) Build(ctx context.Context, req buildRequest) (string, []string, error) {
+	if e.Image == "" {
+		return "", nil, fmt.Errorf("rust: container builds require properties.builderImage to be set (the Lambda execution image has no Rust toolchain)")
+	}
+
+	// Write straight into the same target-dir layout a local build would
+	// produce, rather than a throwaway temp file: there's then nothing for
+	// the caller (or us) to clean up afterward.
+	dst := filepath.Join(req.TargetDir, "lambda", req.HandlerName, "bootstrap")
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", nil, err
+	}
+
+	output, err := builder.Build(ctx, builder.Request{
+		Image:        e.Image,
+		Root:         req.Root,
+		Architecture: req.Architecture,
+		Args:         req.Args,
+		HandlerName:  req.HandlerName,
+		TargetDir:    req.TargetDir,
+		Dst:          dst,
+	})
+	if err != nil {
+		if len(output) == 0 {
+			return "", []string{err.Error()}, nil
+		}
+		return "", []string{string(output)}, nil
+	}
+	return dst, nil, nil
+}
+
+// collectSources reads every .rs file and every Cargo.toml under root, plus
+// root's own Cargo.lock, into memory so they can be uploaded to the remote
+// cache/executor. root is a workspace root whenever the handler resolves
+// to a workspace member (see rust.go's buildRoot), so every member's own
+// Cargo.toml has to come along too -- cargo can't resolve a --package
+// build against an uploaded tree that's missing a member's manifest.
+// Source trees for Lambda handlers are small enough that reading
+// everything into memory is fine; excludes mirror the ones applied when
+// computing the local build cache key.
+func collectSources(root string) (map[string][]byte, error) {
+	paths, err := listSourceFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := listManifestFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(paths)+len(manifests)+1)
+	for _, rel := range append(paths, manifests...) {
+		data, err := os.ReadFile(filepath.Join(root, rel))
+		if err != nil {
+			return nil, fmt.Errorf("remote: reading %s: %w", rel, err)
+		}
+		files[rel] = data
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "Cargo.lock")); err == nil {
+		files["Cargo.lock"] = data
+	}
+	return files, nil
+}
@@ -0,0 +1,129 @@
+package rust
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sst/sst/v3/pkg/runtime/rust/remote"
+)
+
+func TestCollectSourcesIncludesWorkspaceMemberManifests(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "Cargo.toml"), "[workspace]\nmembers = [\"app\", \"common\"]")
+	writeFile(t, filepath.Join(ws, "Cargo.lock"), "lock-v1")
+	writeFile(t, filepath.Join(ws, "app", "Cargo.toml"), "[package]\nname = \"app\"")
+	writeFile(t, filepath.Join(ws, "app", "src", "main.rs"), "fn main() {}")
+	writeFile(t, filepath.Join(ws, "common", "Cargo.toml"), "[package]\nname = \"common\"")
+	writeFile(t, filepath.Join(ws, "common", "src", "lib.rs"), "pub fn hello() {}")
+
+	files, err := collectSources(ws)
+	if err != nil {
+		t.Fatalf("collectSources: %v", err)
+	}
+
+	for _, want := range []string{
+		filepath.Join("app", "Cargo.toml"),
+		filepath.Join("common", "Cargo.toml"),
+		"Cargo.lock",
+		filepath.Join("app", "src", "main.rs"),
+	} {
+		if _, ok := files[want]; !ok {
+			t.Fatalf("expected collectSources to include %q, got %v", want, keysOf(files))
+		}
+	}
+}
+
+func keysOf(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// freeAddr reserves a loopback port by briefly binding then releasing it, so
+// the test server has a concrete address to hand to RemoteExecutor before
+// it actually starts listening.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserving a port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return addr
+}
+
+func TestRemoteExecutorBuildUploadsWorkspaceMemberManifests(t *testing.T) {
+	ws := t.TempDir()
+	writeFile(t, filepath.Join(ws, "Cargo.toml"), "[workspace]\nmembers = [\"app\", \"common\"]")
+	writeFile(t, filepath.Join(ws, "app", "Cargo.toml"), "[package]\nname = \"app\"")
+	writeFile(t, filepath.Join(ws, "app", "src", "main.rs"), "fn main() {}")
+	writeFile(t, filepath.Join(ws, "common", "Cargo.toml"), "[package]\nname = \"common\"")
+	writeFile(t, filepath.Join(ws, "common", "src", "lib.rs"), "pub fn hello() {}")
+
+	srv := remote.NewServer()
+	gotManifest := false
+	srv.Run = func(action remote.Action, blobs map[remote.Digest][]byte) (remote.ActionResult, remote.Digest, []byte) {
+		var manifest map[string]remote.Digest
+		if err := json.Unmarshal(blobs[action.RootDigest], &manifest); err == nil {
+			if d, ok := manifest[filepath.Join("app", "Cargo.toml")]; ok {
+				if _, ok := blobs[d]; ok {
+					gotManifest = true
+				}
+			}
+		}
+		bin := []byte("fake-bootstrap")
+		digest := remote.Digest("out")
+		return remote.ActionResult{ExitCode: 0}, digest, bin
+	}
+
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Serve(ctx, addr)
+
+	var client *remote.Client
+	var err error
+	for i := 0; i < 50; i++ {
+		client, err = remote.Dial(ctx, addr)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("dialing test server: %v", err)
+	}
+	client.Close()
+
+	executor := RemoteExecutor{Endpoint: addr}
+	src, buildErrors, err := executor.buildRemote(ctx, buildRequest{
+		Root:        ws,
+		HandlerName: "app",
+		Args:        []string{"lambda", "build", "--bin", "app", "--package", "app"},
+	})
+	if err != nil {
+		t.Fatalf("buildRemote: %v", err)
+	}
+	if len(buildErrors) > 0 {
+		t.Fatalf("unexpected build errors: %v", buildErrors)
+	}
+	if !gotManifest {
+		t.Fatalf("expected the app workspace member's Cargo.toml to be uploaded")
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("reading built bootstrap: %v", err)
+	}
+	if string(data) != "fake-bootstrap" {
+		t.Fatalf("unexpected bootstrap contents: %q", data)
+	}
+}
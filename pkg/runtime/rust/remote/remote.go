@@ -0,0 +1,262 @@
+// Package remote is a content-addressed build-and-upload client/server pair
+// used by rust.Runtime's Remote executor to offload `cargo lambda build`
+// invocations to a build server. The wire protocol is a small net/rpc
+// service, not gRPC; endpoints are plain "host:port" (a "grpc://" or
+// "tcp://" prefix is accepted and stripped for compatibility with configs
+// written against a future real REAPI backend).
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Digest identifies a blob by the SHA-256 of its contents.
+type Digest string
+
+func digestOf(b []byte) Digest {
+	sum := sha256.Sum256(b)
+	return Digest(hex.EncodeToString(sum[:]))
+}
+
+// Action describes a single remote cargo invocation: the command to run
+// against the uploaded input tree, plus the target/feature selection that
+// affects its output.
+type Action struct {
+	RootDigest   Digest   `json:"rootDigest"`
+	Args         []string `json:"args"`
+	Dir          string   `json:"dir"`
+	Architecture string   `json:"architecture"`
+}
+
+// ActionResult is what the server returns once an Action finishes.
+type ActionResult struct {
+	ExitCode       int    `json:"exitCode"`
+	CombinedOutput string `json:"combinedOutput"`
+	// OutputDigest is the digest of the produced `bootstrap` binary, set
+	// only when ExitCode == 0.
+	OutputDigest Digest `json:"outputDigest,omitempty"`
+}
+
+// FindMissingArgs / FindMissingReply implement the "which blobs do you
+// already have" half of the CAS protocol, so the client only uploads what
+// the server is missing.
+type FindMissingArgs struct{ Digests []Digest }
+type FindMissingReply struct{ Missing []Digest }
+
+type UploadArgs struct {
+	Digest Digest
+	Data   []byte
+}
+type UploadReply struct{}
+
+type DownloadArgs struct{ Digest Digest }
+type DownloadReply struct{ Data []byte }
+
+type ExecuteArgs struct{ Action Action }
+type ExecuteReply struct{ Result ActionResult }
+
+// Client talks to a remote execution server over net/rpc.
+type Client struct {
+	conn *rpc.Client
+}
+
+func Dial(ctx context.Context, endpoint string) (*Client, error) {
+	addr := stripScheme(endpoint)
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("remote: dial %s: %w", addr, err)
+	}
+	return &Client{conn: rpc.NewClient(conn)}, nil
+}
+
+// stripScheme removes a leading "grpc://" or "tcp://" from endpoint, since
+// Properties.Endpoint is documented as accepting either form even though
+// the actual transport is always plain TCP.
+func stripScheme(endpoint string) string {
+	for _, scheme := range []string{"grpc://", "tcp://"} {
+		if rest, ok := strings.CutPrefix(endpoint, scheme); ok {
+			return rest
+		}
+	}
+	return endpoint
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Upload ensures every blob in blobs exists on the server, uploading
+// anything FindMissing reports back. It gives up once ctx is done, so a
+// server that accepts the connection but never replies doesn't hang the
+// build forever.
+func (c *Client) Upload(ctx context.Context, blobs map[Digest][]byte) error {
+	digests := make([]Digest, 0, len(blobs))
+	for d := range blobs {
+		digests = append(digests, d)
+	}
+
+	var missing FindMissingReply
+	if err := c.call(ctx, "Server.FindMissing", &FindMissingArgs{Digests: digests}, &missing); err != nil {
+		return fmt.Errorf("remote: FindMissing: %w", err)
+	}
+
+	for _, d := range missing.Missing {
+		var reply UploadReply
+		if err := c.call(ctx, "Server.Upload", &UploadArgs{Digest: d, Data: blobs[d]}, &reply); err != nil {
+			return fmt.Errorf("remote: Upload %s: %w", d, err)
+		}
+	}
+	return nil
+}
+
+// Execute submits action and blocks until the server completes it or ctx is
+// done.
+func (c *Client) Execute(ctx context.Context, action Action) (ActionResult, error) {
+	var reply ExecuteReply
+	if err := c.call(ctx, "Server.Execute", &ExecuteArgs{Action: action}, &reply); err != nil {
+		return ActionResult{}, fmt.Errorf("remote: Execute: %w", err)
+	}
+	return reply.Result, nil
+}
+
+// Download fetches the blob identified by digest.
+func (c *Client) Download(ctx context.Context, digest Digest) ([]byte, error) {
+	var reply DownloadReply
+	if err := c.call(ctx, "Server.Download", &DownloadArgs{Digest: digest}, &reply); err != nil {
+		return nil, fmt.Errorf("remote: Download %s: %w", digest, err)
+	}
+	return reply.Data, nil
+}
+
+// call runs an RPC and returns ctx.Err() if ctx is done before the server
+// replies, instead of blocking forever -- net/rpc's Call has no built-in
+// deadline support.
+func (c *Client) call(ctx context.Context, serviceMethod string, args, reply any) error {
+	call := c.conn.Go(serviceMethod, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-call.Done:
+		return res.Error
+	}
+}
+
+// BuildTree walks files (relative path -> contents) into a Merkle tree and
+// returns the tree's root digest alongside every blob it references, ready
+// to hand to Upload.
+func BuildTree(files map[string][]byte) (Digest, map[Digest][]byte) {
+	blobs := make(map[Digest][]byte, len(files))
+	manifest := make(map[string]Digest, len(files))
+	for path, data := range files {
+		d := digestOf(data)
+		blobs[d] = data
+		manifest[path] = d
+	}
+	encoded, _ := json.Marshal(manifest)
+	root := digestOf(encoded)
+	blobs[root] = encoded
+	return root, blobs
+}
+
+// Server is an in-memory stub implementation of the RPC surface above,
+// suitable for unit tests and local development; it is not a production
+// build executor.
+type Server struct {
+	mu    sync.Mutex
+	blobs map[Digest][]byte
+	// Run is invoked to actually execute an Action; tests stub it out.
+	Run func(Action, map[Digest][]byte) (ActionResult, Digest, []byte)
+}
+
+func NewServer() *Server {
+	return &Server{blobs: map[Digest][]byte{}}
+}
+
+// Serve starts an rpc server on addr and blocks until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("Server", s); err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return nil
+		}
+		go srv.ServeConn(conn)
+	}
+}
+
+func (s *Server) FindMissing(args *FindMissingArgs, reply *FindMissingReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range args.Digests {
+		if _, ok := s.blobs[d]; !ok {
+			reply.Missing = append(reply.Missing, d)
+		}
+	}
+	return nil
+}
+
+func (s *Server) Upload(args *UploadArgs, reply *UploadReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[args.Digest] = bytes.Clone(args.Data)
+	return nil
+}
+
+func (s *Server) Download(args *DownloadArgs, reply *DownloadReply) error {
+	s.mu.Lock()
+	data, ok := s.blobs[args.Digest]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("remote: unknown digest %s", args.Digest)
+	}
+	reply.Data = data
+	return nil
+}
+
+func (s *Server) Execute(args *ExecuteArgs, reply *ExecuteReply) error {
+	if s.Run == nil {
+		return fmt.Errorf("remote: server has no Run configured")
+	}
+	s.mu.Lock()
+	blobs := make(map[Digest][]byte, len(s.blobs))
+	for k, v := range s.blobs {
+		blobs[k] = v
+	}
+	s.mu.Unlock()
+
+	result, outDigest, outData := s.Run(args.Action, blobs)
+	if outData != nil && result.ExitCode == 0 {
+		s.mu.Lock()
+		s.blobs[outDigest] = outData
+		s.mu.Unlock()
+		result.OutputDigest = outDigest
+	}
+	reply.Result = result
+	return nil
+}
+
+// DefaultTimeout bounds how long a client waits for a remote build before
+// the caller should fall back to a local build.
+const DefaultTimeout = 10 * time.Minute
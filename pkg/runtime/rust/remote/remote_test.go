@@ -0,0 +1,99 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// freeAddr reserves a loopback port by briefly binding then releasing it, so
+// the test has a concrete address to dial before the server starts
+// listening on it.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserving a port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return addr
+}
+
+func dial(t *testing.T, ctx context.Context, addr string) *Client {
+	t.Helper()
+	var client *Client
+	var err error
+	for i := 0; i < 50; i++ {
+		client, err = Dial(ctx, addr)
+		if err == nil {
+			return client
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("dialing %s: %v", addr, err)
+	return nil
+}
+
+func TestExecuteReturnsContextErrorOnTimeout(t *testing.T) {
+	srv := NewServer()
+	block := make(chan struct{})
+	defer close(block)
+	srv.Run = func(action Action, blobs map[Digest][]byte) (ActionResult, Digest, []byte) {
+		<-block
+		return ActionResult{}, "", nil
+	}
+
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Serve(ctx, addr)
+
+	client := dial(t, ctx, addr)
+	defer client.Close()
+
+	shortCtx, shortCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer shortCancel()
+	_, err := client.Execute(shortCtx, Action{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestUploadAndDownloadRoundTrip(t *testing.T) {
+	srv := NewServer()
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Serve(ctx, addr)
+
+	client := dial(t, ctx, addr)
+	defer client.Close()
+
+	root, blobs := BuildTree(map[string][]byte{"Cargo.toml": []byte("[package]\nname = \"a\"")})
+	if err := client.Upload(ctx, blobs); err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+
+	data, err := client.Download(ctx, root)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if string(data) != string(blobs[root]) {
+		t.Fatalf("downloaded manifest doesn't match uploaded one")
+	}
+}
+
+func TestDialStripsSchemePrefix(t *testing.T) {
+	if got := stripScheme("grpc://127.0.0.1:1234"); got != "127.0.0.1:1234" {
+		t.Fatalf("expected prefix stripped, got %q", got)
+	}
+	if got := stripScheme("tcp://127.0.0.1:1234"); got != "127.0.0.1:1234" {
+		t.Fatalf("expected prefix stripped, got %q", got)
+	}
+	if got := stripScheme("127.0.0.1:1234"); got != "127.0.0.1:1234" {
+		t.Fatalf("expected no-op for a bare address, got %q", got)
+	}
+}
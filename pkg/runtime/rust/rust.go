@@ -16,11 +16,30 @@ import (
 	"github.com/sst/sst/v3/internal/fs"
 	"github.com/sst/sst/v3/pkg/process"
 	"github.com/sst/sst/v3/pkg/runtime"
+	"github.com/sst/sst/v3/pkg/runtime/rust/daemon"
 )
 
 type Runtime struct {
-	mut         sync.Mutex
-	directories map[string]string
+	mut sync.Mutex
+	// directories maps a FunctionID to every source directory a rebuild
+	// should be triggered from: just the crate root in the common case, or
+	// every workspace member when the handler lives in one.
+	directories map[string][]string
+	cache       runtime.Cache
+
+	devMut     sync.Mutex
+	queue      *daemon.JobQueue
+	watchers   map[string]*daemon.Watcher
+	devTargets map[string]devTarget
+	devErrors  map[string]string
+}
+
+// devTarget is where a FunctionID's dev-mode watcher writes its freshly
+// rebuilt binary from (src, inside the crate's target dir) and where it
+// needs to end up for the running Worker to pick it up (dst, input.Out()).
+type devTarget struct {
+	src string
+	dst string
 }
 
 type Worker struct {
@@ -56,8 +75,36 @@ func (w *Worker) Logs() io.ReadCloser {
 }
 
 func New() *Runtime {
-	return &Runtime{
-		directories: map[string]string{},
+	r := &Runtime{
+		directories: map[string][]string{},
+		watchers:    map[string]*daemon.Watcher{},
+		devTargets:  map[string]devTarget{},
+		devErrors:   map[string]string{},
+	}
+	if dir := os.Getenv("SST_CACHE_DIR"); dir != "" {
+		if cache, err := runtime.NewFsCache(filepath.Join(dir, "rust")); err == nil {
+			r.cache = cache
+		} else {
+			slog.Error("failed to initialize rust build cache", "err", err)
+		}
+	}
+	r.queue = daemon.NewJobQueue(daemon.DefaultDebounce, r.syncDevBuild)
+	return r
+}
+
+// syncDevBuild copies a FunctionID's latest watcher-built binary into its
+// deployed output path. It's the JobQueue job run once successful cargo
+// watch builds (reported through onWatchEvent) settle, so a burst of
+// finished builds results in one copy instead of one per build.
+func (r *Runtime) syncDevBuild(ctx context.Context, functionID string) {
+	r.devMut.Lock()
+	target, ok := r.devTargets[functionID]
+	r.devMut.Unlock()
+	if !ok {
+		return
+	}
+	if err := copyFile(target.src, target.dst); err != nil {
+		slog.Error("failed to sync dev build", "functionID", functionID, "err", err)
 	}
 }
 
@@ -78,12 +125,48 @@ type CargoTomlBin struct {
 	RequiredFeatures []string `toml:"required-features,omitempty"`
 }
 
+type CargoTomlPackage struct {
+	Name string `toml:"name"`
+}
+
+type CargoTomlWorkspace struct {
+	Members []string `toml:"members"`
+}
+
 type CargoToml struct {
-	Bin []CargoTomlBin `toml:"bin"`
+	Bin       []CargoTomlBin      `toml:"bin"`
+	Package   *CargoTomlPackage   `toml:"package"`
+	Workspace *CargoTomlWorkspace `toml:"workspace"`
 }
 
 type Properties struct {
 	Architecture string `json:"architecture"`
+	// Executor selects where `cargo lambda build` actually runs: "local"
+	// (default) or "remote". Remote requires Endpoint to be set.
+	Executor string `json:"executor"`
+	// Endpoint is the address of the remote execution server, e.g.
+	// "build.internal:7070"; a "grpc://" or "tcp://" prefix is accepted and
+	// stripped. Only used when Executor is "remote".
+	Endpoint string `json:"endpoint"`
+	// Container, when true, runs cargo lambda build inside a pinned
+	// container image instead of on the host toolchain, for byte-identical
+	// builds across machines and CI. Requires BuilderImage to be set.
+	Container bool `json:"container"`
+	// BuilderImage is the container image cargo lambda build runs inside
+	// when Container is true. It must contain a Rust + cargo-lambda
+	// toolchain -- the Lambda execution base images don't have one, so
+	// there's no safe default to fall back to.
+	BuilderImage string `json:"builderImage"`
+}
+
+func (p Properties) executor() Executor {
+	if p.Container {
+		return ContainerExecutor{Image: p.BuilderImage}
+	}
+	if p.Executor == "remote" && p.Endpoint != "" {
+		return RemoteExecutor{Endpoint: p.Endpoint}
+	}
+	return LocalExecutor{}
 }
 
 func (r *Runtime) Build(ctx context.Context, input *runtime.BuildInput) (*runtime.BuildOutput, error) {
@@ -108,15 +191,45 @@ func (r *Runtime) Build(ctx context.Context, input *runtime.BuildInput) (*runtim
 		slog.Error("Error decoding TOML file", "err", err)
 	}
 
+	// buildRoot is where `cargo lambda build` runs: root itself, unless the
+	// handler resolves to a workspace member, in which case it's the
+	// workspace root so cargo can see every member.
+	buildRoot := root
+	// cacheRoot is the directory whose .rs files actually affect this
+	// handler's binary: the resolved crate's own directory, which is root
+	// itself unless the handler lives in a workspace member.
+	cacheRoot := root
 	var requiredFeatures []string
-	for _, v := range cargoToml.Bin {
-		if v.Name == handlerName {
-			requiredFeatures = v.RequiredFeatures
-			break
+	var packageArg string
+	sourceDirs := []string{root}
+
+	workspaceRoot, workspaceToml, isWorkspace := root, &cargoToml, cargoToml.Workspace != nil
+	if !isWorkspace {
+		workspaceRoot, workspaceToml, isWorkspace = findWorkspaceRoot(filepath.Dir(root))
+	}
+
+	resolved := false
+	if isWorkspace {
+		bins := resolveWorkspaceBins(workspaceRoot, workspaceToml.Workspace.Members)
+		if entry, ok := bins[handlerName]; ok {
+			buildRoot = workspaceRoot
+			cacheRoot = entry.Dir
+			requiredFeatures = entry.RequiredFeatures
+			packageArg = entry.Package
+			sourceDirs = absMemberDirs(workspaceRoot, workspaceToml.Workspace.Members)
+			resolved = true
+		}
+	}
+	if !resolved {
+		for _, v := range cargoToml.Bin {
+			if v.Name == handlerName {
+				requiredFeatures = v.RequiredFeatures
+				break
+			}
 		}
 	}
 
-	cargoConfigFile := FindClosestCargoConfig(root)
+	cargoConfigFile := FindClosestCargoConfig(buildRoot)
 
 	var cargoConfig CargoConfig
 	if cargoConfigFile != nil {
@@ -125,8 +238,36 @@ func (r *Runtime) Build(ctx context.Context, input *runtime.BuildInput) (*runtim
 		}
 	}
 
+	dst := filepath.Join(out, "bootstrap")
+
+	var cacheKey string
+	if r.cache != nil {
+		key, err := buildCacheKey(buildRoot, transitiveLocalDeps(cacheRoot), cargoTomlFile, cargoConfigFile, requiredFeatures, properties, input.Dev)
+		if err != nil {
+			slog.Error("failed to compute rust build cache key", "err", err)
+		} else {
+			cacheKey = key
+			if hit, err := r.cache.Get(ctx, cacheKey, dst); err != nil {
+				slog.Error("failed to read rust build cache", "err", err)
+			} else if hit {
+				slog.Info("rust build cache hit", "key", cacheKey)
+				r.directories[input.FunctionID] = absSourceDirs(sourceDirs)
+				return &runtime.BuildOutput{
+					Handler:    "bootstrap",
+					Sourcemaps: []string{},
+					Errors:     []string{},
+					Out:        out,
+				}, nil
+			}
+		}
+	}
+
 	args := []string{"lambda", "build", "--bin", handlerName}
 
+	if packageArg != "" {
+		args = append(args, "--package", packageArg)
+	}
+
 	if !input.Dev {
 		args = append(args, "--release")
 	}
@@ -141,32 +282,43 @@ func (r *Runtime) Build(ctx context.Context, input *runtime.BuildInput) (*runtim
 		args = append(args, "--features", strings.Join(requiredFeatures, ","))
 	}
 
-	cmd := process.Command("cargo", args...)
+	var targetPath string
+	if cargoConfig.Build.TargetDir != nil {
+		targetPath = filepath.Join(buildRoot, *cargoConfig.Build.TargetDir)
+	} else {
+		targetPath = filepath.Join(buildRoot, "target")
+	}
 
-	env := os.Environ()
-	cmd.Dir = root
-	cmd.Env = env
-	slog.Info("running cargo build", "cmd", cmd.Args)
-	output, err := cmd.CombinedOutput()
+	slog.Info("running cargo build", "args", args, "executor", properties.Executor)
+	src, buildErrors, err := properties.executor().Build(ctx, buildRequest{
+		Root:         buildRoot,
+		HandlerName:  handlerName,
+		Args:         args,
+		Architecture: properties.Architecture,
+		TargetDir:    targetPath,
+	})
 	if err != nil {
+		return nil, err
+	}
+	if len(buildErrors) > 0 {
 		return &runtime.BuildOutput{
-			Errors: []string{string(output)},
+			Errors: buildErrors,
 		}, nil
-	} else {
-		var targetPath string
+	}
 
-		if cargoConfig.Build.TargetDir != nil {
-			targetPath = filepath.Join(root, *cargoConfig.Build.TargetDir)
-		} else {
-			targetPath = filepath.Join(root, "target")
-		}
+	copyFile(src, dst)
 
-		src := filepath.Join(targetPath, "lambda", handlerName, "bootstrap")
-		dst := filepath.Join(out, "bootstrap")
+	if r.cache != nil && cacheKey != "" {
+		if err := r.cache.Put(ctx, cacheKey, dst); err != nil {
+			slog.Error("failed to populate rust build cache", "err", err)
+		}
+	}
+	r.directories[input.FunctionID] = absSourceDirs(sourceDirs)
 
-		copyFile(src, dst)
+	if input.Dev {
+		r.ensureWatcher(input.FunctionID, buildRoot, args, src, dst)
 	}
-	r.directories[input.FunctionID], _ = filepath.Abs(root)
+
 	return &runtime.BuildOutput{
 		Handler:    "bootstrap",
 		Sourcemaps: []string{},
@@ -175,6 +327,60 @@ func (r *Runtime) Build(ctx context.Context, input *runtime.BuildInput) (*runtim
 	}, nil
 }
 
+// ensureWatcher starts a persistent `cargo watch` worker for functionID the
+// first time it's seen in dev mode, so subsequent source edits are picked
+// up by incremental compilation instead of a fresh `cargo lambda build`
+// invocation. args is the same cargo lambda build invocation computed for
+// the initial synchronous build.
+func (r *Runtime) ensureWatcher(functionID string, root string, args []string, src string, dst string) {
+	r.devMut.Lock()
+	defer r.devMut.Unlock()
+
+	r.devTargets[functionID] = devTarget{src: src, dst: dst}
+
+	if _, ok := r.watchers[functionID]; ok {
+		return
+	}
+
+	watchArgs := []string{"watch", "-x", strings.Join(args, " ")}
+	watcher, err := daemon.StartWatcher(root, watchArgs, os.Environ(), func(event daemon.WatchEvent) {
+		r.onWatchEvent(functionID, event)
+	})
+	if err != nil {
+		slog.Error("failed to start cargo watch worker", "functionID", functionID, "err", err)
+		return
+	}
+	r.watchers[functionID] = watcher
+}
+
+// onWatchEvent runs whenever the cargo watch worker for functionID finishes
+// a build. A failed build leaves the previous bootstrap binary in place and
+// is logged rather than silently deployed; only a successful build is
+// synced into the deployed output.
+func (r *Runtime) onWatchEvent(functionID string, event daemon.WatchEvent) {
+	if event.ExitCode != 0 {
+		r.devMut.Lock()
+		r.devErrors[functionID] = event.Output
+		r.devMut.Unlock()
+		slog.Error("cargo watch build failed", "functionID", functionID, "output", event.Output)
+		return
+	}
+
+	r.devMut.Lock()
+	delete(r.devErrors, functionID)
+	r.devMut.Unlock()
+	r.queue.Trigger(context.Background(), functionID)
+}
+
+// DevBuildError returns the output of the most recent failed cargo watch
+// build for functionID, if its last build failed.
+func (r *Runtime) DevBuildError(functionID string) (string, bool) {
+	r.devMut.Lock()
+	defer r.devMut.Unlock()
+	output, ok := r.devErrors[functionID]
+	return output, ok
+}
+
 func (r *Runtime) Run(ctx context.Context, input *runtime.RunInput) (runtime.Worker, error) {
 	cmd := process.Command(
 		filepath.Join(input.Build.Out, input.Build.Handler),
@@ -197,16 +403,53 @@ func (r *Runtime) ShouldRebuild(functionID string, file string) bool {
 	if !strings.HasSuffix(file, ".rs") {
 		return false
 	}
-	match, ok := r.directories[functionID]
+	dirs, ok := r.directories[functionID]
 	if !ok {
 		return false
 	}
-	slog.Info("checking if file needs to be rebuilt", "file", file, "match", match)
-	rel, err := filepath.Rel(match, file)
-	if err != nil {
+	matched := false
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			continue
+		}
+		if !strings.HasPrefix(rel, "..") {
+			slog.Info("checking if file needs to be rebuilt", "file", file, "match", dir)
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+
+	r.devMut.Lock()
+	_, hasWatcher := r.watchers[functionID]
+	r.devMut.Unlock()
+	if hasWatcher {
+		// cargo watch already saw this file change and will rebuild on its
+		// own; onWatchEvent syncs the result once that build finishes.
 		return false
 	}
-	return !strings.HasPrefix(rel, "..")
+
+	return true
+}
+
+// Shutdown drains any pending dev-mode rebuild syncs and stops every
+// persistent cargo watch worker. Callers should invoke it when tearing down
+// dev mode so child processes don't outlive it.
+func (r *Runtime) Shutdown(ctx context.Context) error {
+	if err := r.queue.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	r.devMut.Lock()
+	defer r.devMut.Unlock()
+	for functionID, watcher := range r.watchers {
+		watcher.Stop()
+		delete(r.watchers, functionID)
+	}
+	return nil
 }
 
 // FindClosestCargoConfig traverses up the directory tree to find the closest .cargo/config.toml file.
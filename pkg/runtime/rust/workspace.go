@@ -0,0 +1,185 @@
+package rust
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// binEntry is a single resolvable `--bin` target in a workspace member
+// crate. Package is the member's package name, passed as
+// `--package <Package>` to disambiguate; it is empty only when the member's
+// Cargo.toml has no [package] name.
+type binEntry struct {
+	Dir              string
+	Package          string
+	RequiredFeatures []string
+}
+
+// findWorkspaceRoot walks up from dir looking for a Cargo.toml that declares
+// a [workspace] table, stopping at the first one found. Unlike
+// FindClosestCargoConfig this has to inspect file contents, since a member
+// crate's own Cargo.toml sits closer to the handler than the workspace
+// root's does.
+func findWorkspaceRoot(dir string) (string, *CargoToml, bool) {
+	for {
+		candidate := filepath.Join(dir, "Cargo.toml")
+		if _, err := os.Stat(candidate); err == nil {
+			var parsed CargoToml
+			if _, err := toml.DecodeFile(candidate, &parsed); err == nil && parsed.Workspace != nil {
+				return dir, &parsed, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil, false
+		}
+		dir = parent
+	}
+}
+
+// expandMembers resolves a workspace's `members` patterns (which may
+// contain globs like "crates/*") into every directory that has its own
+// Cargo.toml.
+func expandMembers(workspaceRoot string, members []string) []string {
+	var dirs []string
+	for _, pattern := range members {
+		matches, err := filepath.Glob(filepath.Join(workspaceRoot, pattern))
+		if err != nil || len(matches) == 0 {
+			matches = []string{filepath.Join(workspaceRoot, pattern)}
+		}
+		for _, dir := range matches {
+			if _, err := os.Stat(filepath.Join(dir, "Cargo.toml")); err == nil {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// resolveWorkspaceBins merges each workspace member's explicit [[bin]]
+// entries plus auto-discovered `src/bin/*.rs` files into a single name ->
+// binEntry table.
+func resolveWorkspaceBins(workspaceRoot string, members []string) map[string]binEntry {
+	bins := map[string]binEntry{}
+	for _, dir := range expandMembers(workspaceRoot, members) {
+		var member CargoToml
+		if _, err := toml.DecodeFile(filepath.Join(dir, "Cargo.toml"), &member); err != nil {
+			continue
+		}
+		pkgName := ""
+		if member.Package != nil {
+			pkgName = member.Package.Name
+		}
+		addCrateBins(bins, dir, pkgName, member.Bin)
+	}
+	return bins
+}
+
+// addCrateBins registers a crate's explicit [[bin]] table plus any
+// `src/bin/<name>.rs` files cargo builds implicitly, and, when the crate has
+// no explicit [[bin]] table, also registers a plain `src/main.rs` under the
+// package name itself.
+func addCrateBins(bins map[string]binEntry, dir string, pkgName string, explicit []CargoTomlBin) {
+	for _, b := range explicit {
+		if b.Name == "" {
+			continue
+		}
+		bins[b.Name] = binEntry{Dir: dir, Package: pkgName, RequiredFeatures: b.RequiredFeatures}
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(dir, "src", "bin"))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".rs") {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), ".rs")
+		if _, ok := bins[name]; ok {
+			continue
+		}
+		bins[name] = binEntry{Dir: dir, Package: pkgName}
+	}
+
+	if len(explicit) == 0 && pkgName != "" {
+		if _, ok := bins[pkgName]; !ok {
+			if _, err := os.Stat(filepath.Join(dir, "src", "main.rs")); err == nil {
+				bins[pkgName] = binEntry{Dir: dir, Package: pkgName}
+			}
+		}
+	}
+}
+
+// absMemberDirs is expandMembers with every path made absolute, for storing
+// into Runtime.directories.
+func absMemberDirs(workspaceRoot string, members []string) []string {
+	dirs := absSourceDirs(expandMembers(workspaceRoot, members))
+	slog.Debug("resolved workspace members", "workspaceRoot", workspaceRoot, "members", dirs)
+	return dirs
+}
+
+// absSourceDirs resolves every entry in dirs to an absolute path, dropping
+// any that fail to resolve.
+func absSourceDirs(dirs []string) []string {
+	var out []string
+	for _, dir := range dirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			out = append(out, abs)
+		}
+	}
+	return out
+}
+
+// cargoTomlDependencies is a loose view of a crate's [dependencies] table:
+// every value decodes as an opaque toml.Primitive, since most dependency
+// entries are just a version string and we only care about the ones that
+// are a table with a local `path`.
+type cargoTomlDependencies struct {
+	Dependencies map[string]toml.Primitive `toml:"dependencies"`
+}
+
+// localPathDependencyDirs returns the resolved, absolute directories of
+// every `path = "..."` dependency declared in dir's Cargo.toml.
+func localPathDependencyDirs(dir string) []string {
+	var deps cargoTomlDependencies
+	md, err := toml.DecodeFile(filepath.Join(dir, "Cargo.toml"), &deps)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, prim := range deps.Dependencies {
+		var table struct {
+			Path string `toml:"path"`
+		}
+		if err := md.PrimitiveDecode(prim, &table); err != nil || table.Path == "" {
+			continue
+		}
+		dirs = append(dirs, filepath.Clean(filepath.Join(dir, table.Path)))
+	}
+	return dirs
+}
+
+// transitiveLocalDeps returns root plus every directory reachable from it
+// through local path dependencies, so a build's cache key accounts for
+// edits to a shared path-dependency crate (e.g. a `common` library) instead
+// of just the crate actually being built.
+func transitiveLocalDeps(root string) []string {
+	seen := map[string]bool{root: true}
+	queue := []string{root}
+	dirs := []string{root}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		for _, dep := range localPathDependencyDirs(dir) {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			dirs = append(dirs, dep)
+			queue = append(queue, dep)
+		}
+	}
+	return dirs
+}
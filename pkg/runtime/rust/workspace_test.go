@@ -0,0 +1,92 @@
+package rust
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFindWorkspaceRootWalksUpToWorkspaceCargoToml(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "Cargo.toml"), "[workspace]\nmembers = [\"crates/*\"]")
+	writeFile(t, filepath.Join(root, "crates", "a", "Cargo.toml"), "[package]\nname = \"a\"")
+
+	found, parsed, ok := findWorkspaceRoot(filepath.Join(root, "crates", "a"))
+	if !ok {
+		t.Fatalf("expected to find a workspace root")
+	}
+	if found != root {
+		t.Fatalf("expected workspace root %q, got %q", root, found)
+	}
+	if len(parsed.Workspace.Members) != 1 || parsed.Workspace.Members[0] != "crates/*" {
+		t.Fatalf("unexpected members: %v", parsed.Workspace.Members)
+	}
+}
+
+func TestFindWorkspaceRootStopsAtFilesystemRoot(t *testing.T) {
+	dir := t.TempDir()
+	if _, _, ok := findWorkspaceRoot(dir); ok {
+		t.Fatalf("expected no workspace root to be found")
+	}
+}
+
+func TestExpandMembersResolvesGlobs(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "crates", "a", "Cargo.toml"), "[package]\nname = \"a\"")
+	writeFile(t, filepath.Join(root, "crates", "b", "Cargo.toml"), "[package]\nname = \"b\"")
+	// Not a crate, shouldn't be picked up even though it matches the glob.
+	writeFile(t, filepath.Join(root, "crates", "c", "README.md"), "not a crate")
+
+	dirs := expandMembers(root, []string{"crates/*"})
+	if len(dirs) != 2 {
+		t.Fatalf("expected 2 members, got %d: %v", len(dirs), dirs)
+	}
+}
+
+func TestResolveWorkspaceBinsUsesExplicitBinTable(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "crates", "a", "Cargo.toml"), `
+[package]
+name = "a"
+
+[[bin]]
+name = "my-handler"
+required-features = ["lambda"]
+`)
+
+	bins := resolveWorkspaceBins(root, []string{"crates/*"})
+	entry, ok := bins["my-handler"]
+	if !ok {
+		t.Fatalf("expected bin \"my-handler\" to be resolved, got %v", bins)
+	}
+	if entry.Package != "a" {
+		t.Fatalf("expected package \"a\", got %q", entry.Package)
+	}
+	if len(entry.RequiredFeatures) != 1 || entry.RequiredFeatures[0] != "lambda" {
+		t.Fatalf("unexpected required features: %v", entry.RequiredFeatures)
+	}
+	if entry.Dir != filepath.Join(root, "crates", "a") {
+		t.Fatalf("unexpected dir: %q", entry.Dir)
+	}
+}
+
+func TestResolveWorkspaceBinsDiscoversSrcBinFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "crates", "a", "Cargo.toml"), "[package]\nname = \"a\"")
+	writeFile(t, filepath.Join(root, "crates", "a", "src", "bin", "worker.rs"), "fn main() {}")
+
+	bins := resolveWorkspaceBins(root, []string{"crates/*"})
+	if _, ok := bins["worker"]; !ok {
+		t.Fatalf("expected auto-discovered bin \"worker\", got %v", bins)
+	}
+}
+
+func TestResolveWorkspaceBinsFallsBackToPackageNameForMainRs(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "crates", "a", "Cargo.toml"), "[package]\nname = \"a\"")
+	writeFile(t, filepath.Join(root, "crates", "a", "src", "main.rs"), "fn main() {}")
+
+	bins := resolveWorkspaceBins(root, []string{"crates/*"})
+	if _, ok := bins["a"]; !ok {
+		t.Fatalf("expected bin named after package \"a\", got %v", bins)
+	}
+}